@@ -0,0 +1,195 @@
+// Command wifisim runs the Wi-Fi/cellular download simulation described by a
+// scenario config, fanning iterations across concurrent workers and
+// reporting percentile/histogram statistics plus a deadline-miss ratio.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wish-at/wifi/sim"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a YAML or JSON scenario config")
+	seed := flag.Int64("seed", 0, "base RNG seed (0 falls back to the config's seed, then a time-based seed)")
+	concurrency := flag.Int("c", 0, "number of concurrent workers (0 = runtime.GOMAXPROCS)")
+	jsonOut := flag.String("json", "", "optional path to write a machine-readable JSON summary")
+	slaCSVOut := flag.String("sla-csv", "", "optional path to write a per-iteration SLA metrics CSV (requires an sla config block)")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this file")
+	memProfile := flag.String("memprofile", "", "write a memory profile to this file")
+	flag.Parse()
+
+	if *configPath == "" {
+		log.Fatal("wifisim: -config is required")
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			log.Fatalf("wifisim: creating cpu profile: %v", err)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			log.Fatalf("wifisim: starting cpu profile: %v", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	cfg, err := sim.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("wifisim: %v", err)
+	}
+
+	baseSeed := *seed
+	if baseSeed == 0 {
+		baseSeed = cfg.Seed
+	}
+	if baseSeed == 0 {
+		baseSeed = time.Now().UnixNano()
+	}
+
+	runner := sim.Runner{
+		Iterations:  cfg.Iterations,
+		Concurrency: *concurrency,
+		BaseSeed:    baseSeed,
+		NewSimulator: func(rng *rand.Rand) *sim.Simulator {
+			simulator, err := cfg.Build(rng)
+			if err != nil {
+				log.Fatalf("wifisim: %v", err)
+			}
+			return simulator
+		},
+	}
+	if cfg.SLA != nil {
+		metrics := cfg.SLA.Build()
+		runner.SLA = &metrics
+	}
+
+	records, summary := runner.Run()
+	printReport(records, summary)
+
+	if *slaCSVOut != "" {
+		if summary.SLA == nil {
+			log.Fatal("wifisim: -sla-csv requires an sla config block")
+		}
+		if err := writeSLACSV(*slaCSVOut, records); err != nil {
+			log.Fatalf("wifisim: %v", err)
+		}
+	}
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			log.Fatalf("wifisim: creating memory profile: %v", err)
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			log.Fatalf("wifisim: writing memory profile: %v", err)
+		}
+	}
+
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			log.Fatalf("wifisim: marshaling JSON summary: %v", err)
+		}
+		if err := os.WriteFile(*jsonOut, data, 0o644); err != nil {
+			log.Fatalf("wifisim: writing JSON summary: %v", err)
+		}
+	}
+}
+
+func printReport(records []sim.Record, summary sim.Summary) {
+	fmt.Printf("Iterations: %d\n", summary.Iterations)
+	fmt.Printf("Deadline miss ratio: %.5f (%d/%d)\n\n", summary.DeadlineMissRatio, summary.DeadlineMisses, summary.Iterations)
+
+	printStats("Completion time (s)", summary.CompletionSeconds)
+	printStats("Remaining size (Mb)", summary.RemainingMb)
+	printStats("Bytes downloaded (Mb)", summary.BytesDownloadedMb)
+	for _, name := range sortedKeys(summary.LinkBytesMb) {
+		printStats(fmt.Sprintf("Link %q bytes delivered (Mb)", name), summary.LinkBytesMb[name])
+	}
+	if summary.CellularCost != nil {
+		printStats("Cellular cost", *summary.CellularCost)
+	}
+
+	if summary.SLA != nil {
+		fmt.Printf("\nSLA pass ratio: %.5f (%d/%d)\n", summary.SLA.SLAPassRatio, summary.SLA.SLAPasses, summary.SLA.Iterations)
+		printStats("Avg throughput (Mbps)", summary.SLA.AvgThroughputMbps)
+		printStats("Longest outage (s)", summary.SLA.LongestOutageSeconds)
+		printStats("Jain's fairness index", summary.SLA.JainFairnessIndex)
+	}
+
+	completion := make([]float64, len(records))
+	for i, r := range records {
+		completion[i] = r.CompletionSeconds
+	}
+	fmt.Println("\nDownload completion time histogram:")
+	printHistogram(sim.Histogram(completion, 10))
+
+	remaining := make([]float64, len(records))
+	for i, r := range records {
+		remaining[i] = r.RemainingMb
+	}
+	fmt.Println("\nRemaining size histogram:")
+	printHistogram(sim.Histogram(remaining, 10))
+}
+
+func writeSLACSV(path string, records []sim.Record) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("writing SLA CSV: %w", err)
+	}
+	defer f.Close()
+
+	metrics := make([]sim.IterationMetrics, 0, len(records))
+	for _, r := range records {
+		if r.SLA != nil {
+			metrics = append(metrics, *r.SLA)
+		}
+	}
+	return sim.WriteIterationMetricsCSV(f, metrics)
+}
+
+func sortedKeys(m map[string]sim.Stats) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func printStats(label string, s sim.Stats) {
+	fmt.Printf("%s:\n", label)
+	fmt.Printf("  min=%.2f mean=%.2f median=%.2f p90=%.2f p95=%.2f p99=%.2f max=%.2f\n",
+		s.Min, s.Mean, s.Median, s.P90, s.P95, s.P99, s.Max)
+	fmt.Printf("  95%% CI of mean: [%.2f, %.2f]\n", s.CI95Low, s.CI95High)
+}
+
+func printHistogram(buckets []sim.HistogramBucket) {
+	maxCount := 0
+	for _, b := range buckets {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+	}
+	for _, b := range buckets {
+		barLen := 0
+		if maxCount > 0 {
+			barLen = b.Count * 40 / maxCount
+		}
+		fmt.Printf("  <= %8.2f | %-40s %d\n", b.UpperBound, strings.Repeat("#", barLen), b.Count)
+	}
+}
@@ -0,0 +1,354 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DistConfig describes a Distribution by name plus its parameters, so a
+// scenario file can pick any registered distribution without code changes.
+type DistConfig struct {
+	Type      string  `json:"type" yaml:"type"`
+	Mean      float64 `json:"mean,omitempty" yaml:"mean,omitempty"`
+	Alpha     float64 `json:"alpha,omitempty" yaml:"alpha,omitempty"`
+	Xm        float64 `json:"xm,omitempty" yaml:"xm,omitempty"`
+	Mu        float64 `json:"mu,omitempty" yaml:"mu,omitempty"`
+	Sigma     float64 `json:"sigma,omitempty" yaml:"sigma,omitempty"`
+	Shape     float64 `json:"shape,omitempty" yaml:"shape,omitempty"`
+	Scale     float64 `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Value     float64 `json:"value,omitempty" yaml:"value,omitempty"`
+	TracePath string  `json:"trace_path,omitempty" yaml:"trace_path,omitempty"`
+}
+
+// Build constructs the concrete Distribution named by the config.
+func (c DistConfig) Build() (Distribution, error) {
+	switch strings.ToLower(c.Type) {
+	case "exponential":
+		return Exponential{Mean_: c.Mean}, nil
+	case "pareto":
+		return Pareto{Alpha: c.Alpha, Xm: c.Xm}, nil
+	case "lognormal":
+		return LogNormal{Mu: c.Mu, Sigma: c.Sigma}, nil
+	case "weibull":
+		return Weibull{Shape: c.Shape, Scale: c.Scale}, nil
+	case "deterministic":
+		return Deterministic{Value: c.Value}, nil
+	case "empirical":
+		return NewEmpiricalFromCSV(c.TracePath)
+	default:
+		return nil, fmt.Errorf("sim: unknown distribution type %q", c.Type)
+	}
+}
+
+// StateSpecConfig describes one Markov chain state's bandwidth/loss/RTT
+// characteristics.
+type StateSpecConfig struct {
+	Name            string  `json:"name" yaml:"name"`
+	WifiMbps        float64 `json:"wifi_mbps" yaml:"wifi_mbps"`
+	CellularMbps    float64 `json:"cellular_mbps" yaml:"cellular_mbps"`
+	LossProbability float64 `json:"loss_probability,omitempty" yaml:"loss_probability,omitempty"`
+	ExtraRTTMs      float64 `json:"extra_rtt_ms,omitempty" yaml:"extra_rtt_ms,omitempty"`
+}
+
+// MarkovChainConfig describes an N-state continuous-time Markov chain: the
+// per-state spec list plus the N×N transition-rate matrix Q.
+type MarkovChainConfig struct {
+	States     []StateSpecConfig `json:"states" yaml:"states"`
+	RateMatrix [][]float64       `json:"rate_matrix" yaml:"rate_matrix"`
+}
+
+// Build constructs the MarkovChain described by the config.
+func (c MarkovChainConfig) Build() (MarkovChain, error) {
+	n := len(c.States)
+	if n == 0 {
+		return MarkovChain{}, fmt.Errorf("sim: markov_chain config needs at least one state")
+	}
+	if len(c.RateMatrix) != n {
+		return MarkovChain{}, fmt.Errorf("sim: rate_matrix has %d rows, want %d", len(c.RateMatrix), n)
+	}
+
+	specs := make([]StateSpec, n)
+	for i, s := range c.States {
+		specs[i] = StateSpec{
+			Name:            s.Name,
+			WifiMbps:        s.WifiMbps,
+			CellularMbps:    s.CellularMbps,
+			LossProbability: s.LossProbability,
+			ExtraRTT:        time.Duration(s.ExtraRTTMs * float64(time.Millisecond)),
+		}
+	}
+
+	q := make([][]float64, n)
+	for i, row := range c.RateMatrix {
+		if len(row) != n {
+			return MarkovChain{}, fmt.Errorf("sim: rate_matrix row %d has %d entries, want %d", i, len(row), n)
+		}
+		q[i] = append([]float64(nil), row...)
+	}
+
+	return MarkovChain{Specs: specs, Q: q}, nil
+}
+
+// ConnectionSpecConfig describes one ParallelDownloader connection.
+type ConnectionSpecConfig struct {
+	Name      string  `json:"name" yaml:"name"`
+	Radio     string  `json:"radio" yaml:"radio"` // "wifi" or "cellular"
+	RampTauMs float64 `json:"ramp_tau_ms,omitempty" yaml:"ramp_tau_ms,omitempty"`
+	ErrorRate float64 `json:"error_rate,omitempty" yaml:"error_rate,omitempty"`
+}
+
+// ParallelDownloaderConfig describes a ParallelDownloader.
+type ParallelDownloaderConfig struct {
+	Segments    int                    `json:"segments" yaml:"segments"`
+	TickMs      float64                `json:"tick_ms,omitempty" yaml:"tick_ms,omitempty"`
+	Connections []ConnectionSpecConfig `json:"connections" yaml:"connections"`
+}
+
+// Build constructs the ParallelDownloader described by the config.
+func (c ParallelDownloaderConfig) Build() (ParallelDownloader, error) {
+	conns := make([]ConnectionSpec, len(c.Connections))
+	for i, cc := range c.Connections {
+		var radio Radio
+		switch strings.ToLower(cc.Radio) {
+		case "wifi":
+			radio = RadioWifi
+		case "cellular":
+			radio = RadioCellular
+		default:
+			return ParallelDownloader{}, fmt.Errorf("sim: unknown connection radio %q", cc.Radio)
+		}
+		conns[i] = ConnectionSpec{
+			Name:      cc.Name,
+			Radio:     radio,
+			RampTau:   time.Duration(cc.RampTauMs * float64(time.Millisecond)),
+			ErrorRate: cc.ErrorRate,
+		}
+	}
+	return ParallelDownloader{
+		Segments:    c.Segments,
+		Connections: conns,
+		Tick:        time.Duration(c.TickMs * float64(time.Millisecond)),
+	}, nil
+}
+
+// PolicyConfig describes a Policy for PolicyDownloader.
+type PolicyConfig struct {
+	// Type selects the Policy: "always_both" (default), "wifi_only",
+	// "cellular_only_when_disconnected", or "deadline_aware".
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Theta is the finish-probability threshold below which DeadlineAwarePolicy
+	// engages cellular.
+	Theta float64 `json:"theta,omitempty" yaml:"theta,omitempty"`
+	// CostPerMb is charged for every cellular Mb delivered.
+	CostPerMb float64 `json:"cost_per_mb,omitempty" yaml:"cost_per_mb,omitempty"`
+}
+
+// Build constructs the Policy described by the config. DeadlineAwarePolicy
+// needs a MarkovChain to estimate expected future Wi-Fi time, so it borrows
+// the chain cfg's generator would build (or an equivalent one derived from
+// the two-state distributions) even when the scenario itself uses a
+// different generator.
+func (c PolicyConfig) Build(cfg *Config) (Policy, error) {
+	switch strings.ToLower(c.Type) {
+	case "", "always_both":
+		return AlwaysBoth{}, nil
+	case "wifi_only":
+		return WiFiOnly{}, nil
+	case "cellular_only_when_disconnected":
+		return CellularOnlyWhenDisconnected{}, nil
+	case "deadline_aware":
+		chain, err := cfg.chainForPolicy()
+		if err != nil {
+			return nil, err
+		}
+		return DeadlineAwarePolicy{Chain: chain, Theta: c.Theta}, nil
+	default:
+		return nil, fmt.Errorf("sim: unknown policy type %q", c.Type)
+	}
+}
+
+// chainForPolicy returns the MarkovChain a DeadlineAwarePolicy should use to
+// estimate expected future Wi-Fi time, built from the scenario's own
+// markov_chain block if present, or otherwise from its two-state
+// distributions and link speeds.
+func (c *Config) chainForPolicy() (MarkovChain, error) {
+	if c.MarkovChain != nil {
+		return c.MarkovChain.Build()
+	}
+	connect, err := c.ConnectDistribution.Build()
+	if err != nil {
+		return MarkovChain{}, err
+	}
+	disconnect, err := c.DisconnectDistribution.Build()
+	if err != nil {
+		return MarkovChain{}, err
+	}
+	return NewTwoStateMarkovChain(connect.Mean(), disconnect.Mean(), c.WifiMbps, c.MobileMbps), nil
+}
+
+// SLAConfig describes the SLA a scenario's iterations are scored against.
+// Leaving it unset (Config.SLA == nil) disables SLA scoring entirely.
+type SLAConfig struct {
+	MinThroughputMbps float64 `json:"min_throughput_mbps,omitempty" yaml:"min_throughput_mbps,omitempty"`
+	MaxOutageSeconds  float64 `json:"max_outage_seconds,omitempty" yaml:"max_outage_seconds,omitempty"`
+	DeadlineSeconds   float64 `json:"deadline_seconds,omitempty" yaml:"deadline_seconds,omitempty"`
+	SustainedWindowMs float64 `json:"sustained_window_ms,omitempty" yaml:"sustained_window_ms,omitempty"`
+	SampleIntervalMs  float64 `json:"sample_interval_ms,omitempty" yaml:"sample_interval_ms,omitempty"`
+}
+
+// Build constructs the Metrics collector described by the config.
+func (c SLAConfig) Build() Metrics {
+	sampleInterval := time.Duration(c.SampleIntervalMs * float64(time.Millisecond))
+	if sampleInterval <= 0 {
+		sampleInterval = time.Second
+	}
+	return Metrics{
+		SLA: SLA{
+			MinThroughputMbps: c.MinThroughputMbps,
+			MaxOutageSeconds:  c.MaxOutageSeconds,
+			DeadlineSeconds:   c.DeadlineSeconds,
+			SustainedWindow:   time.Duration(c.SustainedWindowMs * float64(time.Millisecond)),
+		},
+		SampleInterval: sampleInterval,
+	}
+}
+
+// Config describes a scenario end to end: how long it runs, how the network
+// state sequence and file size are drawn, and the link speeds available. It
+// is the serializable counterpart of a Simulator, loaded from YAML or JSON
+// so scenarios can be scripted without editing code.
+type Config struct {
+	Iterations     int     `json:"iterations" yaml:"iterations"`
+	SessionSeconds float64 `json:"session_seconds" yaml:"session_seconds"`
+	Seed           int64   `json:"seed" yaml:"seed"`
+
+	// Generator selects the StateGenerator: "two_state" (default) or
+	// "markov_chain".
+	Generator   string             `json:"generator,omitempty" yaml:"generator,omitempty"`
+	MarkovChain *MarkovChainConfig `json:"markov_chain,omitempty" yaml:"markov_chain,omitempty"`
+
+	ConnectDistribution    DistConfig `json:"connect_distribution,omitempty" yaml:"connect_distribution,omitempty"`
+	DisconnectDistribution DistConfig `json:"disconnect_distribution,omitempty" yaml:"disconnect_distribution,omitempty"`
+	FileSizeDistribution   DistConfig `json:"file_size_distribution" yaml:"file_size_distribution"`
+
+	WifiMbps   float64 `json:"wifi_mbps,omitempty" yaml:"wifi_mbps,omitempty"`
+	MobileMbps float64 `json:"mobile_mbps,omitempty" yaml:"mobile_mbps,omitempty"`
+
+	// DownloadStrategy selects the DownloadStrategy: "additive" (default),
+	// "parallel", or "policy".
+	DownloadStrategy string                    `json:"download_strategy,omitempty" yaml:"download_strategy,omitempty"`
+	Parallel         *ParallelDownloaderConfig `json:"parallel,omitempty" yaml:"parallel,omitempty"`
+	Policy           *PolicyConfig             `json:"policy,omitempty" yaml:"policy,omitempty"`
+
+	// SLA, if set, scores every iteration's state sequence against service-
+	// level thresholds instead of the old hard-coded bandwidth check.
+	SLA *SLAConfig `json:"sla,omitempty" yaml:"sla,omitempty"`
+}
+
+// LoadConfig reads a Config from a YAML (.yml/.yaml) or JSON (.json) file,
+// chosen by the file extension.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("sim: reading config: %w", err)
+	}
+
+	cfg := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("sim: parsing YAML config: %w", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("sim: parsing JSON config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("sim: unsupported config extension %q", ext)
+	}
+	return cfg, nil
+}
+
+// Build assembles the Simulator described by the config, using rng as the
+// injectable source of randomness for reproducibility.
+func (c *Config) Build(rng *rand.Rand) (*Simulator, error) {
+	fileSize, err := c.FileSizeDistribution.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	generator, err := c.buildGenerator()
+	if err != nil {
+		return nil, err
+	}
+
+	download, err := c.buildDownloadStrategy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		SessionSeconds: c.SessionSeconds,
+		States:         generator,
+		FileSize:       fileSize,
+		Download:       download,
+		RNG:            rng,
+	}, nil
+}
+
+func (c *Config) buildDownloadStrategy() (DownloadStrategy, error) {
+	switch strategy := strings.ToLower(c.DownloadStrategy); strategy {
+	case "", "additive":
+		return AdditiveDownloader{}, nil
+	case "parallel":
+		if c.Parallel == nil {
+			return nil, fmt.Errorf("sim: download_strategy %q requires a parallel config block", strategy)
+		}
+		return c.Parallel.Build()
+	case "policy":
+		if c.Policy == nil {
+			return nil, fmt.Errorf("sim: download_strategy %q requires a policy config block", strategy)
+		}
+		policy, err := c.Policy.Build(c)
+		if err != nil {
+			return nil, err
+		}
+		return PolicyDownloader{Policy: policy, CostPerMb: c.Policy.CostPerMb}, nil
+	default:
+		return nil, fmt.Errorf("sim: unknown download_strategy %q", c.DownloadStrategy)
+	}
+}
+
+func (c *Config) buildGenerator() (StateGenerator, error) {
+	switch generator := strings.ToLower(c.Generator); generator {
+	case "", "two_state":
+		connect, err := c.ConnectDistribution.Build()
+		if err != nil {
+			return nil, err
+		}
+		disconnect, err := c.DisconnectDistribution.Build()
+		if err != nil {
+			return nil, err
+		}
+		return TwoStateGenerator{
+			Connect:        connect,
+			Disconnect:     disconnect,
+			ConnectSpec:    StateSpec{Name: StateConnect, WifiMbps: c.WifiMbps, CellularMbps: c.MobileMbps},
+			DisconnectSpec: StateSpec{Name: StateDisconnect, CellularMbps: c.MobileMbps},
+		}, nil
+	case "markov_chain":
+		if c.MarkovChain == nil {
+			return nil, fmt.Errorf("sim: generator %q requires a markov_chain config block", generator)
+		}
+		return c.MarkovChain.Build()
+	default:
+		return nil, fmt.Errorf("sim: unknown generator %q", c.Generator)
+	}
+}
@@ -0,0 +1,157 @@
+package sim
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Distribution is a pluggable source of non-negative random samples, used both
+// for sojourn times in a StateGenerator and for file-size sampling.
+type Distribution interface {
+	// Sample draws one realization using rng.
+	Sample(rng *rand.Rand) float64
+	// Mean returns the distribution's expected value.
+	Mean() float64
+}
+
+// Exponential is a memoryless distribution parameterized directly by its mean,
+// matching the original InverseCDFExponential helper.
+type Exponential struct {
+	Mean_ float64
+}
+
+func (e Exponential) Sample(rng *rand.Rand) float64 {
+	return InverseCDFExponential(rng.Float64(), e.Mean_)
+}
+
+func (e Exponential) Mean() float64 { return e.Mean_ }
+
+// InverseCDFExponential inverts the exponential CDF for a mean of val at
+// uniform sample u.
+func InverseCDFExponential(u, val float64) float64 {
+	return (-val) * math.Log(1-u)
+}
+
+// Pareto is the original file-size distribution, parameterized by shape alpha
+// and minimum value xm.
+type Pareto struct {
+	Alpha float64
+	Xm    float64
+}
+
+func (p Pareto) Sample(rng *rand.Rand) float64 {
+	u := rng.Float64()
+	return p.Xm / math.Pow(u, 1.0/p.Alpha)
+}
+
+func (p Pareto) Mean() float64 {
+	if p.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return p.Alpha * p.Xm / (p.Alpha - 1)
+}
+
+// LogNormal draws samples whose logarithm is normally distributed with mean
+// Mu and standard deviation Sigma.
+type LogNormal struct {
+	Mu    float64
+	Sigma float64
+}
+
+func (l LogNormal) Sample(rng *rand.Rand) float64 {
+	return math.Exp(l.Mu + l.Sigma*rng.NormFloat64())
+}
+
+func (l LogNormal) Mean() float64 {
+	return math.Exp(l.Mu + l.Sigma*l.Sigma/2)
+}
+
+// Weibull draws samples with the given Shape (k) and Scale (lambda).
+type Weibull struct {
+	Shape float64
+	Scale float64
+}
+
+func (w Weibull) Sample(rng *rand.Rand) float64 {
+	u := rng.Float64()
+	return w.Scale * math.Pow(-math.Log(1-u), 1/w.Shape)
+}
+
+func (w Weibull) Mean() float64 {
+	return w.Scale * math.Gamma(1+1/w.Shape)
+}
+
+// Deterministic always returns the same Value; useful for sanity-checking a
+// scenario against a fixed sojourn time or file size.
+type Deterministic struct {
+	Value float64
+}
+
+func (d Deterministic) Sample(rng *rand.Rand) float64 { return d.Value }
+func (d Deterministic) Mean() float64                 { return d.Value }
+
+// Empirical samples by inverting the empirical CDF built from a trace of
+// observed durations, so a scenario can be driven by real connect/disconnect
+// measurements instead of a closed-form distribution.
+type Empirical struct {
+	sorted []float64
+	mean   float64
+}
+
+// NewEmpiricalFromCSV builds an Empirical distribution from a single-column
+// CSV of observed durations (one value per line, optional header row of
+// non-numeric text is skipped).
+func NewEmpiricalFromCSV(path string) (*Empirical, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("sim: opening empirical trace: %w", err)
+	}
+	defer f.Close()
+
+	var values []float64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		field := strings.Split(line, ",")[0]
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			continue // skip header/non-numeric rows
+		}
+		values = append(values, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("sim: reading empirical trace: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("sim: empirical trace %q has no numeric samples", path)
+	}
+
+	sort.Float64s(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return &Empirical{sorted: values, mean: sum / float64(len(values))}, nil
+}
+
+// Sample draws a uniform quantile and returns the corresponding order
+// statistic from the trace, i.e. inverts the empirical CDF.
+func (e *Empirical) Sample(rng *rand.Rand) float64 {
+	u := rng.Float64()
+	idx := int(u * float64(len(e.sorted)))
+	if idx >= len(e.sorted) {
+		idx = len(e.sorted) - 1
+	}
+	return e.sorted[idx]
+}
+
+func (e *Empirical) Mean() float64 { return e.mean }
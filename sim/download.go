@@ -0,0 +1,62 @@
+package sim
+
+import "math/rand"
+
+// LinkReport summarizes one logical connection's contribution to a
+// DownloadResult, for strategies (like ParallelDownloader) that split work
+// across more than one link.
+type LinkReport struct {
+	Name             string
+	BytesDeliveredMb float64
+}
+
+// DownloadResult summarizes the outcome of running a DownloadStrategy over a
+// state sequence.
+type DownloadResult struct {
+	RemainingMb       float64
+	BytesDownloadedMb float64
+	CompletionSeconds float64
+	Completed         bool
+	// LinkReports is populated by strategies that track delivery per link
+	// (PolicyDownloader reports a single "cellular" entry); it is empty for
+	// AdditiveDownloader.
+	LinkReports []LinkReport
+	// CellularCost is the monetary cost incurred from cellular bytes,
+	// populated by strategies (like PolicyDownloader) that charge for it.
+	CellularCost float64
+	// CostAware is true for strategies that actually track CellularCost
+	// (currently just PolicyDownloader), so callers can tell a real zero
+	// cost apart from a strategy that never priced cellular at all.
+	CostAware bool
+}
+
+// DownloadStrategy models how a file is pulled down across a sequence of
+// network states, each of which carries its own bandwidth spec.
+type DownloadStrategy interface {
+	Download(rng *rand.Rand, states []State, fileSizeMb float64) DownloadResult
+}
+
+// AdditiveDownloader treats each state as a single pipe with wifi+cellular
+// throughput summed, derated by the state's loss probability, with its
+// ExtraRTT eating into the usable portion of the sojourn. This is the
+// original SimulateDownload behavior, generalized from a hard-coded
+// Connect/Disconnect check to whatever bandwidth the current state's spec
+// carries.
+type AdditiveDownloader struct{}
+
+func (AdditiveDownloader) Download(rng *rand.Rand, states []State, fileSizeMb float64) DownloadResult {
+	remaining := fileSizeMb
+	elapsed := 0.0
+	for _, s := range states {
+		usable := s.Spec.UsableSeconds(s.T)
+		throughput := s.Spec.EffectiveMbps(s.Spec.WifiMbps + s.Spec.CellularMbps)
+		delivered := throughput * usable
+		if delivered >= remaining && throughput > 0 {
+			elapsed += (s.T - usable) + remaining/throughput
+			return DownloadResult{RemainingMb: 0, BytesDownloadedMb: fileSizeMb, CompletionSeconds: elapsed, Completed: true}
+		}
+		remaining -= delivered
+		elapsed += s.T
+	}
+	return DownloadResult{RemainingMb: remaining, BytesDownloadedMb: fileSizeMb - remaining, CompletionSeconds: elapsed, Completed: false}
+}
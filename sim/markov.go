@@ -0,0 +1,170 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// MarkovChain is a continuous-time Markov chain over N network states,
+// parameterized by a transition-rate matrix Q (off-diagonal Q[i][j] is the
+// rate of transitioning from state i to state j; Q[i][i] is the negative sum
+// of i's outgoing rates) and the bandwidth/loss/RTT spec in effect for each
+// state. It generalizes the original hard-coded 2-state Connect/Disconnect
+// flip to an arbitrary number of states (e.g. Wi-Fi-good, Wi-Fi-degraded,
+// 5G-only, 4G-only, offline).
+type MarkovChain struct {
+	Specs []StateSpec
+	Q     [][]float64
+}
+
+// NewTwoStateMarkovChain builds the classic Connect/Disconnect chain from
+// mean sojourn times, matching the model the simulator originally hard-coded
+// (a 2-state CTMC is necessarily exponential).
+func NewTwoStateMarkovChain(connectMeanSeconds, disconnectMeanSeconds, wifiMbps, cellularMbps float64) MarkovChain {
+	rOutOfConnect := 1 / connectMeanSeconds
+	rOutOfDisconnect := 1 / disconnectMeanSeconds
+	return MarkovChain{
+		Specs: []StateSpec{
+			{Name: StateConnect, WifiMbps: wifiMbps, CellularMbps: cellularMbps},
+			{Name: StateDisconnect, CellularMbps: cellularMbps},
+		},
+		Q: [][]float64{
+			{-rOutOfConnect, rOutOfConnect},
+			{rOutOfDisconnect, -rOutOfDisconnect},
+		},
+	}
+}
+
+// Stationary solves πQ = 0, π·1 = 1 for the chain's stationary distribution.
+func (m MarkovChain) Stationary() ([]float64, error) {
+	n := len(m.Specs)
+	if n == 0 {
+		return nil, fmt.Errorf("sim: markov chain has no states")
+	}
+	if n == 1 {
+		return []float64{1}, nil
+	}
+
+	// A = Q^T, with the last equation replaced by the normalization
+	// constraint sum(pi) = 1.
+	a := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		a[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			a[i][j] = m.Q[j][i]
+		}
+	}
+	for j := 0; j < n; j++ {
+		a[n-1][j] = 1
+	}
+	b := make([]float64, n)
+	b[n-1] = 1
+
+	return solveLinearSystem(a, b)
+}
+
+// solveLinearSystem solves Ax = b via Gaussian elimination with partial
+// pivoting, mutating a and b in place.
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, error) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("sim: singular transition-rate matrix")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
+
+// initState draws a starting state index from the chain's stationary
+// distribution, replacing the old two-state closed-form
+// p0 = E[T0]/(E[T0]+E[T1]).
+func (m MarkovChain) initState(rng *rand.Rand) int {
+	pi, err := m.Stationary()
+	if err != nil {
+		return 0
+	}
+	u := rng.Float64()
+	cum := 0.0
+	for i, p := range pi {
+		cum += p
+		if u <= cum {
+			return i
+		}
+	}
+	return len(pi) - 1
+}
+
+// nextState picks j != i with probability -Q[i][j]/Q[i][i].
+func (m MarkovChain) nextState(rng *rand.Rand, i int) int {
+	rate := -m.Q[i][i]
+	u := rng.Float64()
+	cum := 0.0
+	last := i
+	for j := range m.Specs {
+		if j == i {
+			continue
+		}
+		cum += m.Q[i][j] / rate
+		last = j
+		if u <= cum {
+			return j
+		}
+	}
+	return last
+}
+
+// Generate walks the chain Gillespie-style: in state i, draw a sojourn
+// τ ~ Exp(-Q[i][i]), then pick the next state with probability
+// -Q[i][j]/Q[i][i]. The final sojourn is clamped to whatever remains of the
+// session; an absorbing state (rate 0) holds for the rest of the session.
+func (m MarkovChain) Generate(rng *rand.Rand, sessionSeconds float64) []State {
+	i := m.initState(rng)
+
+	var states []State
+	elapsed := 0.0
+	for elapsed < sessionSeconds {
+		rate := -m.Q[i][i]
+		var tau float64
+		if rate <= 0 {
+			tau = sessionSeconds - elapsed
+		} else {
+			tau = InverseCDFExponential(rng.Float64(), 1/rate)
+		}
+		if elapsed+tau > sessionSeconds {
+			tau = sessionSeconds - elapsed
+		}
+		states = append(states, State{Spec: m.Specs[i], T: tau})
+		elapsed += tau
+		if elapsed >= sessionSeconds || rate <= 0 {
+			break
+		}
+		i = m.nextState(rng, i)
+	}
+	return states
+}
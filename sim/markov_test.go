@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarkovChainStationaryTwoState(t *testing.T) {
+	// connectMean=10s (exit rate 0.1), disconnectMean=5s (exit rate 0.2).
+	// Detailed balance for a 2-state chain gives pi_connect = rOutDisconnect /
+	// (rOutConnect + rOutDisconnect) = 0.2/0.3 = 2/3.
+	chain := NewTwoStateMarkovChain(10, 5, 60, 20)
+
+	pi, err := chain.Stationary()
+	if err != nil {
+		t.Fatalf("Stationary() error: %v", err)
+	}
+
+	want := []float64{2.0 / 3, 1.0 / 3}
+	for i, w := range want {
+		if math.Abs(pi[i]-w) > 1e-9 {
+			t.Errorf("pi[%d] = %v, want %v", i, pi[i], w)
+		}
+	}
+}
+
+func TestMarkovChainStationaryThreeStateCirculant(t *testing.T) {
+	// A circulant rate matrix (every column sums to 0 by construction) has a
+	// uniform stationary distribution: pi Q = 0 holds column-wise for
+	// pi = [1/3, 1/3, 1/3] regardless of the specific rates.
+	chain := MarkovChain{
+		Specs: []StateSpec{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+		Q: [][]float64{
+			{-0.3, 0.2, 0.1},
+			{0.1, -0.3, 0.2},
+			{0.2, 0.1, -0.3},
+		},
+	}
+
+	pi, err := chain.Stationary()
+	if err != nil {
+		t.Fatalf("Stationary() error: %v", err)
+	}
+
+	for i, p := range pi {
+		if math.Abs(p-1.0/3) > 1e-9 {
+			t.Errorf("pi[%d] = %v, want 1/3", i, p)
+		}
+	}
+
+	sum := pi[0] + pi[1] + pi[2]
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("sum(pi) = %v, want 1", sum)
+	}
+}
+
+func TestMarkovChainStationarySingleState(t *testing.T) {
+	chain := MarkovChain{Specs: []StateSpec{{Name: "only"}}, Q: [][]float64{{0}}}
+
+	pi, err := chain.Stationary()
+	if err != nil {
+		t.Fatalf("Stationary() error: %v", err)
+	}
+	if len(pi) != 1 || pi[0] != 1 {
+		t.Errorf("pi = %v, want [1]", pi)
+	}
+}
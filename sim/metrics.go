@@ -0,0 +1,84 @@
+package sim
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Metrics scores iterations against a fixed SLA, sampling throughput every
+// SampleInterval (default 1s if zero).
+type Metrics struct {
+	SLA            SLA
+	SampleInterval time.Duration
+}
+
+// Evaluate scores one iteration's state sequence against m.SLA.
+func (m Metrics) Evaluate(states []State, completionSeconds float64) IterationMetrics {
+	return EvaluateSLA(states, completionSeconds, m.SLA, m.SampleInterval)
+}
+
+// MetricsSummary aggregates IterationMetrics across a whole run.
+type MetricsSummary struct {
+	Iterations           int        `json:"iterations"`
+	SLAPasses            int        `json:"sla_passes"`
+	SLAPassRatio         float64    `json:"sla_pass_ratio"`
+	AvgThroughputMbps    Stats      `json:"avg_throughput_mbps"`
+	LongestOutageSeconds Stats      `json:"longest_outage_seconds"`
+	JainFairnessIndex    Stats      `json:"jain_fairness_index"`
+	ThroughputCDF        []CDFPoint `json:"throughput_cdf,omitempty"`
+}
+
+// SummarizeMetrics aggregates per-iteration SLA metrics into a MetricsSummary.
+// The aggregate ThroughputCDF is built from each iteration's average
+// throughput, i.e. it describes the distribution of per-iteration
+// throughput rather than of the raw within-iteration samples.
+func SummarizeMetrics(all []IterationMetrics) MetricsSummary {
+	avgThroughput := make([]float64, len(all))
+	longestOutage := make([]float64, len(all))
+	jain := make([]float64, len(all))
+	passes := 0
+	for i, m := range all {
+		avgThroughput[i] = m.AvgThroughputMbps
+		longestOutage[i] = m.LongestOutageSeconds
+		jain[i] = m.JainFairnessIndex
+		if m.Pass {
+			passes++
+		}
+	}
+
+	return MetricsSummary{
+		Iterations:           len(all),
+		SLAPasses:            passes,
+		SLAPassRatio:         float64(passes) / float64(len(all)),
+		AvgThroughputMbps:    computeStats(avgThroughput),
+		LongestOutageSeconds: computeStats(longestOutage),
+		JainFairnessIndex:    computeStats(jain),
+		ThroughputCDF:        ThroughputCDF(avgThroughput),
+	}
+}
+
+// WriteIterationMetricsCSV writes one row per iteration for downstream
+// plotting: average throughput, longest outage, Jain's fairness index, and
+// SLA pass/fail.
+func WriteIterationMetricsCSV(w io.Writer, all []IterationMetrics) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"avg_throughput_mbps", "longest_outage_seconds", "jain_fairness_index", "sla_pass"}); err != nil {
+		return err
+	}
+	for _, m := range all {
+		row := []string{
+			strconv.FormatFloat(m.AvgThroughputMbps, 'f', -1, 64),
+			strconv.FormatFloat(m.LongestOutageSeconds, 'f', -1, 64),
+			strconv.FormatFloat(m.JainFairnessIndex, 'f', -1, 64),
+			strconv.FormatBool(m.Pass),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
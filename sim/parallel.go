@@ -0,0 +1,195 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Radio identifies which physical link a ParallelDownloader connection
+// draws its throughput cap from.
+type Radio int
+
+const (
+	RadioWifi Radio = iota
+	RadioCellular
+)
+
+// ConnectionSpec describes one logical connection in a ParallelDownloader,
+// e.g. one stream riding Wi-Fi and another riding cellular.
+type ConnectionSpec struct {
+	Name string
+	// Radio selects which of the current state's bandwidth caps this
+	// connection draws from.
+	Radio Radio
+	// RampTau is the TCP slow-start time constant: throughput grows as
+	// min(cap, cap*(1-exp(-t/tau))) after each state change. Zero disables
+	// the ramp (the connection jumps straight to cap).
+	RampTau time.Duration
+	// ErrorRate is the probability, per second, that the in-flight chunk is
+	// lost and must be retried.
+	ErrorRate float64
+}
+
+type segment struct {
+	remainingMb float64
+}
+
+type connectionState struct {
+	spec        ConnectionSpec
+	current     *segment
+	timeInState float64
+	delivered   float64
+}
+
+// ParallelDownloader models the file as Segments equal byte-range segments
+// spread across several logical connections, each with its own per-state
+// throughput cap, TCP slow-start ramp, and error rate — matching how
+// MPTCP / parallel-segment downloaders behave. A state's LossProbability
+// derates every connection's throughput cap while that state is active, and
+// its ExtraRTT is burned as dead time before transfer resumes in the new
+// state. When a connection runs out of queued segments it work-steals from
+// whichever connection holds the most remaining bytes in its current
+// segment, splitting that range in half.
+type ParallelDownloader struct {
+	Segments    int
+	Connections []ConnectionSpec
+	// Tick is the simulation time step; smaller values trade CPU time for
+	// ramp/error-rate accuracy. Defaults to 200ms if zero.
+	Tick time.Duration
+}
+
+func (d ParallelDownloader) Download(rng *rand.Rand, states []State, fileSizeMb float64) DownloadResult {
+	if len(d.Connections) == 0 || d.Segments <= 0 {
+		return AdditiveDownloader{}.Download(rng, states, fileSizeMb)
+	}
+
+	tick := d.Tick
+	if tick <= 0 {
+		tick = 200 * time.Millisecond
+	}
+	dt := tick.Seconds()
+
+	segSize := fileSizeMb / float64(d.Segments)
+	var queue []*segment
+	for i := 0; i < d.Segments; i++ {
+		queue = append(queue, &segment{remainingMb: segSize})
+	}
+
+	conns := make([]*connectionState, len(d.Connections))
+	for i, spec := range d.Connections {
+		conns[i] = &connectionState{spec: spec}
+	}
+
+	assignWork := func(c *connectionState) {
+		if c.current != nil {
+			return
+		}
+		if len(queue) > 0 {
+			c.current, queue = queue[0], queue[1:]
+			c.timeInState = 0
+			return
+		}
+		var victim *connectionState
+		for _, other := range conns {
+			if other == c || other.current == nil {
+				continue
+			}
+			if victim == nil || other.current.remainingMb > victim.current.remainingMb {
+				victim = other
+			}
+		}
+		if victim == nil || victim.current.remainingMb <= 0 {
+			return
+		}
+		half := victim.current.remainingMb / 2
+		victim.current.remainingMb -= half
+		c.current = &segment{remainingMb: half}
+		c.timeInState = 0
+	}
+
+	elapsed := 0.0
+	remainingTotal := fileSizeMb
+
+	for _, st := range states {
+		if remainingTotal <= 0 {
+			break
+		}
+		usableT := st.Spec.UsableSeconds(st.T)
+		elapsed += st.T - usableT // ExtraRTT: dead time before transfer resumes in this state
+
+		for _, c := range conns {
+			c.timeInState = 0 // slow-start restarts on every network state change, e.g. a reconnect
+		}
+
+		stateElapsed := 0.0
+		for stateElapsed < usableT {
+			step := dt
+			if stateElapsed+step > usableT {
+				step = usableT - stateElapsed
+			}
+
+			for _, c := range conns {
+				assignWork(c)
+				if c.current == nil {
+					c.timeInState += step
+					continue
+				}
+
+				capMbps := 0.0
+				switch c.spec.Radio {
+				case RadioWifi:
+					capMbps = st.Spec.WifiMbps
+				case RadioCellular:
+					capMbps = st.Spec.CellularMbps
+				}
+				capMbps = st.Spec.EffectiveMbps(capMbps)
+
+				throughput := capMbps
+				if c.spec.RampTau > 0 {
+					throughput = capMbps * (1 - math.Exp(-c.timeInState/c.spec.RampTau.Seconds()))
+				}
+				c.timeInState += step
+
+				if capMbps <= 0 {
+					continue
+				}
+				if c.spec.ErrorRate > 0 && rng.Float64() < c.spec.ErrorRate*step {
+					continue // chunk lost this tick, retried next tick
+				}
+
+				deliveredMb := throughput * step
+				if deliveredMb > c.current.remainingMb {
+					deliveredMb = c.current.remainingMb
+				}
+				c.current.remainingMb -= deliveredMb
+				c.delivered += deliveredMb
+				remainingTotal -= deliveredMb
+
+				if c.current.remainingMb <= 0 {
+					c.current = nil
+				}
+			}
+
+			stateElapsed += step
+			elapsed += step
+			if remainingTotal <= 0 {
+				break
+			}
+		}
+	}
+
+	remainingTotal = math.Max(remainingTotal, 0)
+	reports := make([]LinkReport, len(conns))
+	for i, c := range conns {
+		reports[i] = LinkReport{Name: c.spec.Name, BytesDeliveredMb: c.delivered}
+	}
+
+	return DownloadResult{
+		RemainingMb:       remainingTotal,
+		BytesDownloadedMb: fileSizeMb - remainingTotal,
+		CompletionSeconds: elapsed,
+		Completed:         remainingTotal <= 0,
+		LinkReports:       reports,
+	}
+}
@@ -0,0 +1,122 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestParallelDownloaderRampResetsOnStateChange(t *testing.T) {
+	d := ParallelDownloader{
+		Segments:    1,
+		Connections: []ConnectionSpec{{Name: "wifi", Radio: RadioWifi, RampTau: 2 * time.Second}},
+		Tick:        100 * time.Millisecond,
+	}
+
+	wifi := StateSpec{WifiMbps: 100}
+	offline := StateSpec{WifiMbps: 0}
+
+	withDisconnect := []State{
+		{Spec: wifi, T: 10},
+		{Spec: offline, T: 5},
+		{Spec: wifi, T: 10},
+	}
+	continuous := []State{{Spec: wifi, T: 20}}
+
+	withDisconnectResult := d.Download(rand.New(rand.NewSource(1)), withDisconnect, 1e9)
+	continuousResult := d.Download(rand.New(rand.NewSource(1)), continuous, 1e9)
+
+	// Both sequences offer 20s of wifi time in total, but reconnecting after
+	// the 5s disconnect should restart slow-start from zero: if it didn't,
+	// the two results would come out equal.
+	if withDisconnectResult.BytesDownloadedMb >= continuousResult.BytesDownloadedMb {
+		t.Errorf("reconnect delivered %.4f Mb, continuous delivered %.4f Mb; reconnect should deliver strictly less (ramp must restart after the disconnect)",
+			withDisconnectResult.BytesDownloadedMb, continuousResult.BytesDownloadedMb)
+	}
+}
+
+func TestParallelDownloaderNoRampJumpsStraightToCap(t *testing.T) {
+	d := ParallelDownloader{
+		Segments:    1,
+		Connections: []ConnectionSpec{{Name: "wifi", Radio: RadioWifi}}, // RampTau: 0
+		Tick:        100 * time.Millisecond,
+	}
+	states := []State{{Spec: StateSpec{WifiMbps: 10}, T: 10}}
+
+	result := d.Download(rand.New(rand.NewSource(1)), states, 50)
+
+	if !result.Completed || result.RemainingMb != 0 {
+		t.Fatalf("result = %+v, want a completed download with nothing remaining", result)
+	}
+	wantSeconds := 5.0 // 50Mb at 10Mbps
+	if diff := result.CompletionSeconds - wantSeconds; diff < -0.2 || diff > 0.2 {
+		t.Errorf("CompletionSeconds = %v, want ~%v", result.CompletionSeconds, wantSeconds)
+	}
+}
+
+func TestParallelDownloaderWorkSteals(t *testing.T) {
+	// Two equal-size segments, two connections with very different caps: the
+	// fast connection should finish its segment, then steal from the slow
+	// one instead of sitting idle.
+	d := ParallelDownloader{
+		Segments: 2,
+		Connections: []ConnectionSpec{
+			{Name: "fast", Radio: RadioWifi},
+			{Name: "slow", Radio: RadioCellular},
+		},
+		Tick: 50 * time.Millisecond,
+	}
+	states := []State{{Spec: StateSpec{WifiMbps: 1000, CellularMbps: 1}, T: 30}}
+
+	result := d.Download(rand.New(rand.NewSource(1)), states, 100)
+
+	if !result.Completed {
+		t.Fatalf("result = %+v, want Completed", result)
+	}
+
+	var slowDelivered float64
+	for _, link := range result.LinkReports {
+		if link.Name == "slow" {
+			slowDelivered = link.BytesDeliveredMb
+		}
+	}
+	if slowDelivered >= 50 {
+		t.Errorf("slow connection delivered %.4f Mb of its original 50Mb segment; want less, since the fast connection should have stolen part of it", slowDelivered)
+	}
+}
+
+func TestParallelDownloaderErrorRateReducesThroughput(t *testing.T) {
+	states := []State{{Spec: StateSpec{WifiMbps: 100}, T: 10}}
+
+	reliable := ParallelDownloader{
+		Segments:    1,
+		Connections: []ConnectionSpec{{Name: "wifi", Radio: RadioWifi}},
+		Tick:        100 * time.Millisecond,
+	}
+	lossy := ParallelDownloader{
+		Segments:    1,
+		Connections: []ConnectionSpec{{Name: "wifi", Radio: RadioWifi, ErrorRate: 5}}, // 5/s * 0.1s tick: a 50% chance of losing each tick
+		Tick:        100 * time.Millisecond,
+	}
+
+	reliableResult := reliable.Download(rand.New(rand.NewSource(1)), states, 1e9)
+	lossyResult := lossy.Download(rand.New(rand.NewSource(1)), states, 1e9)
+
+	if lossyResult.BytesDownloadedMb >= reliableResult.BytesDownloadedMb {
+		t.Errorf("lossy delivered %.4f Mb, reliable delivered %.4f Mb; lossy should deliver strictly less",
+			lossyResult.BytesDownloadedMb, reliableResult.BytesDownloadedMb)
+	}
+}
+
+func TestParallelDownloaderFallsBackToAdditiveWhenUnconfigured(t *testing.T) {
+	states := []State{{Spec: StateSpec{WifiMbps: 50, CellularMbps: 10}, T: 10}}
+
+	d := ParallelDownloader{} // no Connections, no Segments
+	got := d.Download(rand.New(rand.NewSource(1)), states, 100)
+	want := AdditiveDownloader{}.Download(rand.New(rand.NewSource(1)), states, 100)
+
+	if got.RemainingMb != want.RemainingMb || got.BytesDownloadedMb != want.BytesDownloadedMb ||
+		got.CompletionSeconds != want.CompletionSeconds || got.Completed != want.Completed {
+		t.Errorf("unconfigured ParallelDownloader = %+v, want AdditiveDownloader's result %+v", got, want)
+	}
+}
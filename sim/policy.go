@@ -0,0 +1,70 @@
+package sim
+
+// Policy decides, for the state the session is about to spend time in,
+// whether cellular should be engaged alongside Wi-Fi.
+type Policy interface {
+	// UseCellular reports whether cellular should be engaged for state,
+	// given how much session time and file size remain.
+	UseCellular(state State, remainingSeconds, remainingMb float64) bool
+}
+
+// AlwaysBoth always engages cellular alongside Wi-Fi; this is the original
+// SimulateDownload behavior (sum wifi+mobile on Connect, mobile alone on
+// Disconnect).
+type AlwaysBoth struct{}
+
+func (AlwaysBoth) UseCellular(State, float64, float64) bool { return true }
+
+// WiFiOnly never engages cellular, regardless of how the download is going.
+type WiFiOnly struct{}
+
+func (WiFiOnly) UseCellular(State, float64, float64) bool { return false }
+
+// CellularOnlyWhenDisconnected engages cellular only in states with no
+// Wi-Fi at all, never burning cellular data while Wi-Fi is available.
+type CellularOnlyWhenDisconnected struct{}
+
+func (CellularOnlyWhenDisconnected) UseCellular(state State, _, _ float64) bool {
+	return state.Spec.WifiMbps == 0
+}
+
+// DeadlineAwarePolicy engages cellular only when Wi-Fi alone looks unlikely
+// to finish the download in time. It estimates the expected remaining
+// Wi-Fi-connected time as the chain's long-run fraction of time spent in a
+// Wi-Fi-available state times the session time remaining, then engages
+// cellular once the resulting finish-probability proxy drops below Theta.
+type DeadlineAwarePolicy struct {
+	Chain MarkovChain
+	Theta float64
+}
+
+func (p DeadlineAwarePolicy) UseCellular(state State, remainingSeconds, remainingMb float64) bool {
+	if remainingMb <= 0 {
+		return false
+	}
+
+	expectedWifiSeconds := p.wifiStationaryMass() * remainingSeconds
+	expectedWifiMb := expectedWifiSeconds * state.Spec.WifiMbps
+
+	finishProb := 1.0
+	if expectedWifiMb < remainingMb {
+		finishProb = expectedWifiMb / remainingMb
+	}
+	return finishProb < p.Theta
+}
+
+// wifiStationaryMass returns the chain's stationary probability of being in
+// a state with any Wi-Fi bandwidth at all.
+func (p DeadlineAwarePolicy) wifiStationaryMass() float64 {
+	pi, err := p.Chain.Stationary()
+	if err != nil {
+		return 0
+	}
+	mass := 0.0
+	for i, spec := range p.Chain.Specs {
+		if spec.WifiMbps > 0 && i < len(pi) {
+			mass += pi[i]
+		}
+	}
+	return mass
+}
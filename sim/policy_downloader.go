@@ -0,0 +1,56 @@
+package sim
+
+import "math/rand"
+
+// PolicyDownloader is a single-pipe downloader like AdditiveDownloader, but
+// consults a Policy before each state to decide whether cellular should be
+// engaged alongside Wi-Fi, tracking the cellular bytes used and their cost.
+type PolicyDownloader struct {
+	Policy    Policy
+	CostPerMb float64
+}
+
+func (d PolicyDownloader) Download(rng *rand.Rand, states []State, fileSizeMb float64) DownloadResult {
+	remaining := fileSizeMb
+	elapsed := 0.0
+	cellularMb := 0.0
+	consumedSeconds := 0.0
+	totalSeconds := SumOfTinState(states)
+
+	for _, s := range states {
+		remainingSeconds := totalSeconds - consumedSeconds
+		cellular := 0.0
+		if d.Policy.UseCellular(s, remainingSeconds, remaining) {
+			cellular = s.Spec.CellularMbps
+		}
+		usable := s.Spec.UsableSeconds(s.T)
+		throughput := s.Spec.EffectiveMbps(s.Spec.WifiMbps + cellular)
+
+		delivered := throughput * usable
+		if delivered >= remaining && throughput > 0 {
+			frac := remaining / throughput
+			elapsed += (s.T - usable) + frac
+			cellularMb += cellular * frac
+			return d.result(cellularMb, 0, fileSizeMb, elapsed, true)
+		}
+
+		remaining -= delivered
+		elapsed += s.T
+		cellularMb += cellular * usable
+		consumedSeconds += s.T
+	}
+
+	return d.result(cellularMb, remaining, fileSizeMb-remaining, elapsed, false)
+}
+
+func (d PolicyDownloader) result(cellularMb, remainingMb, bytesDownloadedMb, elapsed float64, completed bool) DownloadResult {
+	return DownloadResult{
+		RemainingMb:       remainingMb,
+		BytesDownloadedMb: bytesDownloadedMb,
+		CompletionSeconds: elapsed,
+		Completed:         completed,
+		LinkReports:       []LinkReport{{Name: "cellular", BytesDeliveredMb: cellularMb}},
+		CellularCost:      cellularMb * d.CostPerMb,
+		CostAware:         true,
+	}
+}
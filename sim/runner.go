@@ -0,0 +1,160 @@
+package sim
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// Record is the set of per-iteration measurements a Runner collects.
+type Record struct {
+	CompletionSeconds float64
+	BytesDownloadedMb float64
+	RemainingMb       float64
+	Transitions       int
+	DeadlineMissed    bool
+	LinkReports       []LinkReport
+	CellularCost      float64
+	CostAware         bool
+	SLA               *IterationMetrics
+}
+
+// Summary aggregates Records across a whole run.
+type Summary struct {
+	Iterations        int              `json:"iterations"`
+	DeadlineMisses    int              `json:"deadline_misses"`
+	DeadlineMissRatio float64          `json:"deadline_miss_ratio"`
+	CompletionSeconds Stats            `json:"completion_seconds"`
+	RemainingMb       Stats            `json:"remaining_mb"`
+	BytesDownloadedMb Stats            `json:"bytes_downloaded_mb"`
+	LinkBytesMb       map[string]Stats `json:"link_bytes_mb,omitempty"`
+	// CellularCost is set only when the run's DownloadStrategy actually
+	// tracks cellular cost (PolicyDownloader); nil for strategies that never
+	// priced cellular, so a real zero cost isn't confused with "not
+	// applicable".
+	CellularCost *Stats          `json:"cellular_cost,omitempty"`
+	SLA          *MetricsSummary `json:"sla,omitempty"`
+}
+
+// Runner fans Monte-Carlo iterations of a scenario across concurrent
+// workers. Each iteration draws its own *rand.Rand seeded deterministically
+// from BaseSeed and the iteration index (not the worker or scheduling
+// order), so the aggregate result is reproducible for a given BaseSeed
+// regardless of Concurrency.
+type Runner struct {
+	Iterations   int
+	Concurrency  int
+	BaseSeed     int64
+	NewSimulator func(rng *rand.Rand) *Simulator
+
+	// SLA, if set, is evaluated against every iteration's state sequence.
+	SLA *Metrics
+}
+
+// Run executes all iterations and returns the per-iteration records plus an
+// aggregated Summary.
+func (r Runner) Run() ([]Record, Summary) {
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	records := make([]Record, r.Iterations)
+	work := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			simulator := r.NewSimulator(rand.New(rand.NewSource(r.BaseSeed + int64(workerID) + 1)))
+			for i := range work {
+				simulator.RNG = rand.New(rand.NewSource(r.BaseSeed + int64(i) + 1))
+				result := simulator.RunOnce()
+				rec := Record{
+					CompletionSeconds: result.CompletionSeconds,
+					BytesDownloadedMb: result.BytesDownloadedMb,
+					RemainingMb:       result.RemainingMb,
+					Transitions:       len(result.States),
+					DeadlineMissed:    result.DeadlineMissed,
+					LinkReports:       result.LinkReports,
+					CellularCost:      result.CellularCost,
+					CostAware:         result.CostAware,
+				}
+				if r.SLA != nil {
+					m := r.SLA.Evaluate(result.States, result.CompletionSeconds)
+					rec.SLA = &m
+				}
+				records[i] = rec
+			}
+		}(w)
+	}
+
+	for i := 0; i < r.Iterations; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	return records, Summarize(records)
+}
+
+// Summarize aggregates a slice of Records into a Summary.
+func Summarize(records []Record) Summary {
+	completion := make([]float64, len(records))
+	remaining := make([]float64, len(records))
+	bytes := make([]float64, len(records))
+	var cost []float64
+	linkBytes := map[string][]float64{}
+	slaMetrics := make([]IterationMetrics, 0, len(records))
+	misses := 0
+	for i, rec := range records {
+		completion[i] = rec.CompletionSeconds
+		remaining[i] = rec.RemainingMb
+		bytes[i] = rec.BytesDownloadedMb
+		if rec.DeadlineMissed {
+			misses++
+		}
+		for _, link := range rec.LinkReports {
+			linkBytes[link.Name] = append(linkBytes[link.Name], link.BytesDeliveredMb)
+		}
+		if rec.CostAware {
+			cost = append(cost, rec.CellularCost)
+		}
+		if rec.SLA != nil {
+			slaMetrics = append(slaMetrics, *rec.SLA)
+		}
+	}
+
+	var linkStats map[string]Stats
+	if len(linkBytes) > 0 {
+		linkStats = make(map[string]Stats, len(linkBytes))
+		for name, values := range linkBytes {
+			linkStats[name] = computeStats(values)
+		}
+	}
+
+	var costStats *Stats
+	if len(cost) > 0 {
+		s := computeStats(cost)
+		costStats = &s
+	}
+
+	var slaSummary *MetricsSummary
+	if len(slaMetrics) > 0 {
+		s := SummarizeMetrics(slaMetrics)
+		slaSummary = &s
+	}
+
+	return Summary{
+		Iterations:        len(records),
+		DeadlineMisses:    misses,
+		DeadlineMissRatio: float64(misses) / float64(len(records)),
+		CompletionSeconds: computeStats(completion),
+		RemainingMb:       computeStats(remaining),
+		BytesDownloadedMb: computeStats(bytes),
+		LinkBytesMb:       linkStats,
+		CellularCost:      costStats,
+		SLA:               slaSummary,
+	}
+}
@@ -0,0 +1,49 @@
+package sim
+
+import "math/rand"
+
+// IterationResult captures the outcome of a single simulated download.
+type IterationResult struct {
+	States            []State
+	FileSizeMb        float64
+	RemainingMb       float64
+	BytesDownloadedMb float64
+	CompletionSeconds float64
+	DeadlineMissed    bool
+	LinkReports       []LinkReport
+	CellularCost      float64
+	CostAware         bool
+}
+
+// Simulator runs one session: it generates a state sequence, draws a file
+// size, and downloads it according to a DownloadStrategy. RNG must be
+// supplied by the caller so runs are reproducible from a fixed seed instead
+// of relying on the global math/rand source.
+type Simulator struct {
+	SessionSeconds float64
+	States         StateGenerator
+	FileSize       Distribution
+	Download       DownloadStrategy
+	RNG            *rand.Rand
+}
+
+// RunOnce simulates a single session end to end.
+func (s *Simulator) RunOnce() IterationResult {
+	fileSizeMB := s.FileSize.Sample(s.RNG)
+	fileSizeMb := fileSizeMB * 8
+
+	states := s.States.Generate(s.RNG, s.SessionSeconds)
+	result := s.Download.Download(s.RNG, states, fileSizeMb)
+
+	return IterationResult{
+		States:            states,
+		FileSizeMb:        fileSizeMb,
+		RemainingMb:       result.RemainingMb,
+		BytesDownloadedMb: result.BytesDownloadedMb,
+		CompletionSeconds: result.CompletionSeconds,
+		DeadlineMissed:    !result.Completed,
+		LinkReports:       result.LinkReports,
+		CellularCost:      result.CellularCost,
+		CostAware:         result.CostAware,
+	}
+}
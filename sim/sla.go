@@ -0,0 +1,167 @@
+package sim
+
+import (
+	"sort"
+	"time"
+)
+
+// SLA describes the service-level thresholds a session is evaluated
+// against, replacing the old hard-coded "average bandwidth > 40 Mbps" flag.
+type SLA struct {
+	MinThroughputMbps float64
+	MaxOutageSeconds  float64
+	DeadlineSeconds   float64
+	SustainedWindow   time.Duration
+}
+
+// CDFPoint is one point of an empirical CDF: the fraction of samples at or
+// below Mbps.
+type CDFPoint struct {
+	Mbps       float64
+	Cumulative float64
+}
+
+// ThroughputCDF builds the empirical CDF of a set of throughput samples.
+func ThroughputCDF(samples []float64) []CDFPoint {
+	if len(samples) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	points := make([]CDFPoint, len(sorted))
+	for i, v := range sorted {
+		points[i] = CDFPoint{Mbps: v, Cumulative: float64(i+1) / float64(len(sorted))}
+	}
+	return points
+}
+
+// IterationMetrics is the SLA-oriented summary of a single iteration's state
+// sequence.
+type IterationMetrics struct {
+	AvgThroughputMbps    float64
+	LongestOutageSeconds float64
+	ThroughputCDF        []CDFPoint
+	JainFairnessIndex    float64
+	Pass                 bool
+}
+
+// EvaluateSLA scores a state sequence against sla. It samples instantaneous
+// throughput every sampleInterval to build a per-iteration CDF and to feed
+// Jain's fairness index over SLA.SustainedWindow, and separately derives the
+// time-weighted average throughput and the longest contiguous outage (a run
+// of states whose combined throughput stays below SLA.MinThroughputMbps)
+// directly from the state durations.
+func EvaluateSLA(states []State, completionSeconds float64, sla SLA, sampleInterval time.Duration) IterationMetrics {
+	if sampleInterval <= 0 {
+		sampleInterval = time.Second
+	}
+	totalSeconds := SumOfTinState(states)
+
+	weightedSum := 0.0
+	outage := 0.0
+	longestOutage := 0.0
+	for _, s := range states {
+		bw := s.Spec.EffectiveMbps(s.Spec.WifiMbps + s.Spec.CellularMbps)
+		weightedSum += bw * s.T
+		if bw < sla.MinThroughputMbps {
+			outage += s.T
+			if outage > longestOutage {
+				longestOutage = outage
+			}
+		} else {
+			outage = 0
+		}
+	}
+	avgThroughput := 0.0
+	if totalSeconds > 0 {
+		avgThroughput = weightedSum / totalSeconds
+	}
+
+	samples := sampleThroughput(states, sampleInterval)
+
+	pass := avgThroughput >= sla.MinThroughputMbps &&
+		longestOutage <= sla.MaxOutageSeconds &&
+		completionSeconds <= sla.DeadlineSeconds
+
+	return IterationMetrics{
+		AvgThroughputMbps:    avgThroughput,
+		LongestOutageSeconds: longestOutage,
+		ThroughputCDF:        ThroughputCDF(samples),
+		JainFairnessIndex:    slidingJainFairness(samples, sampleInterval, sla.SustainedWindow),
+		Pass:                 pass,
+	}
+}
+
+// sampleThroughput samples the instantaneous (state-capacity) throughput
+// every interval across the full state sequence.
+func sampleThroughput(states []State, interval time.Duration) []float64 {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	step := interval.Seconds()
+
+	var samples []float64
+	stateIdx := 0
+	elapsedInState := 0.0
+	for t := 0.0; stateIdx < len(states); t += step {
+		for stateIdx < len(states) && elapsedInState >= states[stateIdx].T {
+			elapsedInState -= states[stateIdx].T
+			stateIdx++
+		}
+		if stateIdx >= len(states) {
+			break
+		}
+		s := states[stateIdx]
+		samples = append(samples, s.Spec.EffectiveMbps(s.Spec.WifiMbps+s.Spec.CellularMbps))
+		elapsedInState += step
+	}
+	return samples
+}
+
+// jainFairness computes Jain's fairness index J(x) = (sum x)^2 / (n * sum x^2)
+// over a set of throughput samples; 1.0 means perfectly even, lower means
+// burstier. A window of all-zero throughput is reported as perfectly fair
+// (1.0), per the standard definition; SLA.MaxOutageSeconds is what actually
+// catches a sustained blackout.
+func jainFairness(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sum, sumSq := 0.0, 0.0
+	for _, x := range samples {
+		sum += x
+		sumSq += x * x
+	}
+	if sumSq == 0 {
+		return 1
+	}
+	n := float64(len(samples))
+	return (sum * sum) / (n * sumSq)
+}
+
+// slidingJainFairness reports the worst (minimum) Jain's fairness index
+// observed over any window of length `window`, stepping one sample at a
+// time. A window <= 0, or one spanning the whole sample set, falls back to
+// a single fairness index over all samples.
+func slidingJainFairness(samples []float64, sampleInterval, window time.Duration) float64 {
+	if window <= 0 || sampleInterval <= 0 || len(samples) == 0 {
+		return jainFairness(samples)
+	}
+
+	windowSize := int(window / sampleInterval)
+	if windowSize < 1 {
+		windowSize = 1
+	}
+	if windowSize >= len(samples) {
+		return jainFairness(samples)
+	}
+
+	worst := 1.0
+	for start := 0; start+windowSize <= len(samples); start++ {
+		if idx := jainFairness(samples[start : start+windowSize]); idx < worst {
+			worst = idx
+		}
+	}
+	return worst
+}
@@ -0,0 +1,112 @@
+package sim
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func mbpsSpec(mbps float64) StateSpec {
+	return StateSpec{WifiMbps: mbps}
+}
+
+func TestEvaluateSLA(t *testing.T) {
+	// 10s at 50Mbps, a 3s outage, then 10s at 50Mbps: 23s total.
+	states := []State{
+		{Spec: mbpsSpec(50), T: 10},
+		{Spec: mbpsSpec(0), T: 3},
+		{Spec: mbpsSpec(50), T: 10},
+	}
+	sla := SLA{MinThroughputMbps: 40, MaxOutageSeconds: 5, DeadlineSeconds: 25}
+
+	m := EvaluateSLA(states, 23, sla, time.Second)
+
+	wantAvg := (10*50.0 + 3*0 + 10*50.0) / 23
+	if math.Abs(m.AvgThroughputMbps-wantAvg) > 1e-9 {
+		t.Errorf("AvgThroughputMbps = %v, want %v", m.AvgThroughputMbps, wantAvg)
+	}
+	if m.LongestOutageSeconds != 3 {
+		t.Errorf("LongestOutageSeconds = %v, want 3", m.LongestOutageSeconds)
+	}
+	if !m.Pass {
+		t.Errorf("Pass = false, want true (avg %.2f >= 40, outage 3 <= 5, completion 23 <= 25)", m.AvgThroughputMbps)
+	}
+}
+
+func TestEvaluateSLAFailsOnLongerOutageThanAllowed(t *testing.T) {
+	// Average is (5*100 + 10*0 + 5*100) / 20 = 50 >= 40, so only the outage
+	// can fail the SLA.
+	states := []State{
+		{Spec: mbpsSpec(100), T: 5},
+		{Spec: mbpsSpec(0), T: 10}, // outage longer than MaxOutageSeconds
+		{Spec: mbpsSpec(100), T: 5},
+	}
+	sla := SLA{MinThroughputMbps: 40, MaxOutageSeconds: 5, DeadlineSeconds: 100}
+
+	m := EvaluateSLA(states, 20, sla, time.Second)
+
+	if m.LongestOutageSeconds != 10 {
+		t.Errorf("LongestOutageSeconds = %v, want 10", m.LongestOutageSeconds)
+	}
+	if m.Pass {
+		t.Error("Pass = true, want false (outage of 10s exceeds MaxOutageSeconds of 5s)")
+	}
+}
+
+func TestEvaluateSLAFailsOnMissedDeadline(t *testing.T) {
+	states := []State{{Spec: mbpsSpec(100), T: 30}}
+	sla := SLA{MinThroughputMbps: 40, MaxOutageSeconds: 5, DeadlineSeconds: 20}
+
+	m := EvaluateSLA(states, 30, sla, time.Second)
+
+	if m.Pass {
+		t.Error("Pass = true, want false (completion 30s exceeds DeadlineSeconds of 20s)")
+	}
+}
+
+func TestJainFairness(t *testing.T) {
+	cases := []struct {
+		name    string
+		samples []float64
+		want    float64
+	}{
+		{"perfectly even", []float64{1, 1, 1, 1}, 1},
+		{"maximally uneven", []float64{2, 0, 0, 0}, 0.25},
+		{"empty", nil, 0},
+		{"all zero", []float64{0, 0, 0}, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := jainFairness(c.samples); math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("jainFairness(%v) = %v, want %v", c.samples, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSlidingJainFairnessFindsWorstWindow(t *testing.T) {
+	// A steady run of 1s, then a single burst to 4, then steady 1s again.
+	// Any window straddling the burst should be less fair than 1.0.
+	samples := []float64{1, 1, 1, 1, 4, 1, 1, 1, 1}
+	got := slidingJainFairness(samples, time.Second, 2*time.Second)
+	if got >= 1 {
+		t.Errorf("slidingJainFairness = %v, want < 1 (a window containing the burst should be less than perfectly fair)", got)
+	}
+}
+
+func TestThroughputCDF(t *testing.T) {
+	points := ThroughputCDF([]float64{30, 10, 20})
+	want := []CDFPoint{
+		{Mbps: 10, Cumulative: 1.0 / 3},
+		{Mbps: 20, Cumulative: 2.0 / 3},
+		{Mbps: 30, Cumulative: 1},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("len(points) = %d, want %d", len(points), len(want))
+	}
+	for i, p := range points {
+		if p.Mbps != want[i].Mbps || math.Abs(p.Cumulative-want[i].Cumulative) > 1e-9 {
+			t.Errorf("points[%d] = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
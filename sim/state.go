@@ -0,0 +1,125 @@
+package sim
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// StateConnect marks an interval where Wi-Fi is available alongside
+	// cellular.
+	StateConnect = "Connect"
+	// StateDisconnect marks an interval where only cellular is available.
+	StateDisconnect = "Disconnect"
+)
+
+// StateSpec describes the link characteristics available while a session
+// occupies a given network state.
+type StateSpec struct {
+	Name            string
+	WifiMbps        float64
+	CellularMbps    float64
+	LossProbability float64
+	ExtraRTT        time.Duration
+}
+
+// State represents one sojourn: the spec in effect and the duration T (in
+// seconds) the session stayed there before transitioning.
+type State struct {
+	Spec StateSpec
+	T    float64
+}
+
+// EffectiveMbps derates a raw throughput cap (e.g. WifiMbps, CellularMbps, or
+// their sum) by the state's loss probability, modeling lost bytes as goodput
+// that never lands.
+func (s StateSpec) EffectiveMbps(capMbps float64) float64 {
+	return capMbps * (1 - s.LossProbability)
+}
+
+// UsableSeconds returns how much of a sojourn of length t is left for
+// transfer once ExtraRTT overhead — connection setup, retransmission delay —
+// is subtracted from the front of it.
+func (s StateSpec) UsableSeconds(t float64) float64 {
+	usable := t - s.ExtraRTT.Seconds()
+	if usable < 0 {
+		return 0
+	}
+	return usable
+}
+
+// SumOfTinState sums the T values in a slice of State structs.
+func SumOfTinState(states []State) float64 {
+	sum := 0.0
+	for _, s := range states {
+		sum += s.T
+	}
+	return sum
+}
+
+// StateGenerator produces the sequence of network states a session traverses
+// over its lifetime.
+type StateGenerator interface {
+	Generate(rng *rand.Rand, sessionSeconds float64) []State
+}
+
+// TwoStateGenerator alternates between Connect and Disconnect sojourns drawn
+// from Connect and Disconnect respectively. Unlike MarkovChain, the sojourn
+// distributions here are pluggable (Weibull, Pareto, ...) rather than fixed
+// to the exponential a CTMC implies, so it's the right choice when a
+// scenario wants a non-memoryless 2-state model.
+type TwoStateGenerator struct {
+	Connect        Distribution
+	Disconnect     Distribution
+	ConnectSpec    StateSpec
+	DisconnectSpec StateSpec
+}
+
+// initState picks the starting state from the stationary distribution of the
+// two-state chain, p0 = E[T0]/(E[T0]+E[T1]).
+func (g TwoStateGenerator) initState(rng *rand.Rand) string {
+	p0 := g.Disconnect.Mean() / (g.Disconnect.Mean() + g.Connect.Mean())
+	if rng.Float64() <= p0 {
+		return StateDisconnect
+	}
+	return StateConnect
+}
+
+func nextTwoState(state string) string {
+	if state == StateDisconnect {
+		return StateConnect
+	}
+	return StateDisconnect
+}
+
+// Generate builds the alternating Connect/Disconnect sequence until the
+// session time is exhausted, clamping the final sojourn to whatever remains.
+func (g TwoStateGenerator) Generate(rng *rand.Rand, sessionSeconds float64) []State {
+	var states []State
+	currentState := g.initState(rng)
+
+	for {
+		var dist Distribution
+		var spec StateSpec
+		if currentState == StateDisconnect {
+			dist, spec = g.Disconnect, g.DisconnectSpec
+		} else {
+			dist, spec = g.Connect, g.ConnectSpec
+		}
+
+		t := dist.Sample(rng)
+		if t > sessionSeconds {
+			t = sessionSeconds
+		}
+		if SumOfTinState(states)+t >= sessionSeconds {
+			remaining := sessionSeconds - SumOfTinState(states)
+			if remaining > 0 {
+				states = append(states, State{Spec: spec, T: remaining})
+			}
+			break
+		}
+		states = append(states, State{Spec: spec, T: t})
+		currentState = nextTwoState(currentState)
+	}
+	return states
+}
@@ -0,0 +1,121 @@
+package sim
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats summarizes a sample of float64 observations with the usual
+// percentile ladder plus a 95% confidence interval on the mean.
+type Stats struct {
+	Count    int     `json:"count"`
+	Min      float64 `json:"min"`
+	Mean     float64 `json:"mean"`
+	Median   float64 `json:"median"`
+	P90      float64 `json:"p90"`
+	P95      float64 `json:"p95"`
+	P99      float64 `json:"p99"`
+	Max      float64 `json:"max"`
+	StdDev   float64 `json:"std_dev"`
+	CI95Low  float64 `json:"ci95_low"`
+	CI95High float64 `json:"ci95_high"`
+}
+
+// computeStats sorts a copy of values and derives Stats from it. An empty
+// slice yields a zero Stats.
+func computeStats(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	variance := 0.0
+	for _, v := range sorted {
+		d := v - mean
+		variance += d * d
+	}
+	variance /= float64(len(sorted))
+	stdDev := math.Sqrt(variance)
+	stdErr := stdDev / math.Sqrt(float64(len(sorted)))
+
+	return Stats{
+		Count:    len(sorted),
+		Min:      sorted[0],
+		Mean:     mean,
+		Median:   percentile(sorted, 0.50),
+		P90:      percentile(sorted, 0.90),
+		P95:      percentile(sorted, 0.95),
+		P99:      percentile(sorted, 0.99),
+		Max:      sorted[len(sorted)-1],
+		StdDev:   stdDev,
+		CI95Low:  mean - 1.96*stdErr,
+		CI95High: mean + 1.96*stdErr,
+	}
+}
+
+// percentile returns the value at quantile q (0..1) of an already-sorted
+// slice, using linear interpolation between neighboring order statistics.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
+
+// HistogramBucket is one bar of a Histogram: the count of observations with
+// value < UpperBound (and >= the previous bucket's UpperBound, or the
+// minimum for the first bucket). The last bucket also holds the maximum.
+type HistogramBucket struct {
+	UpperBound float64
+	Count      int
+}
+
+// Histogram buckets values into the given number of equal-width buckets
+// spanning [min, max].
+func Histogram(values []float64, buckets int) []HistogramBucket {
+	if len(values) == 0 || buckets <= 0 {
+		return nil
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	width := (max - min) / float64(buckets)
+	if width == 0 {
+		return []HistogramBucket{{UpperBound: max, Count: len(values)}}
+	}
+
+	result := make([]HistogramBucket, buckets)
+	for i := range result {
+		result[i].UpperBound = min + width*float64(i+1)
+	}
+	for _, v := range values {
+		idx := int((v - min) / width)
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		result[idx].Count++
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	s := computeStats(values)
+
+	if s.Count != 5 {
+		t.Errorf("Count = %d, want 5", s.Count)
+	}
+	if s.Min != 1 || s.Max != 5 {
+		t.Errorf("Min/Max = %v/%v, want 1/5", s.Min, s.Max)
+	}
+	if s.Mean != 3 {
+		t.Errorf("Mean = %v, want 3", s.Mean)
+	}
+	if s.Median != 3 {
+		t.Errorf("Median = %v, want 3", s.Median)
+	}
+	wantStdDev := math.Sqrt(2) // population variance of 1..5 is 2
+	if math.Abs(s.StdDev-wantStdDev) > 1e-9 {
+		t.Errorf("StdDev = %v, want %v", s.StdDev, wantStdDev)
+	}
+	if s.CI95Low > s.Mean || s.CI95High < s.Mean {
+		t.Errorf("CI [%v, %v] does not bracket the mean %v", s.CI95Low, s.CI95High, s.Mean)
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	if s := computeStats(nil); s != (Stats{}) {
+		t.Errorf("computeStats(nil) = %+v, want zero Stats", s)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40, 50}
+
+	if got := percentile(sorted, 0.5); got != 30 {
+		t.Errorf("median = %v, want 30", got)
+	}
+	if got := percentile(sorted, 0); got != 10 {
+		t.Errorf("p0 = %v, want 10", got)
+	}
+	if got := percentile(sorted, 1); got != 50 {
+		t.Errorf("p100 = %v, want 50", got)
+	}
+	// 0.25 * (5-1) = 1 lands exactly on the 2nd order statistic (index 1).
+	if got := percentile(sorted, 0.25); got != 20 {
+		t.Errorf("p25 = %v, want 20", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	buckets := Histogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5)
+	if len(buckets) != 5 {
+		t.Fatalf("len(buckets) = %d, want 5", len(buckets))
+	}
+
+	total := 0
+	for _, b := range buckets {
+		total += b.Count
+	}
+	if total != 11 {
+		t.Errorf("total bucket count = %d, want 11", total)
+	}
+	if buckets[len(buckets)-1].UpperBound != 10 {
+		t.Errorf("last bucket UpperBound = %v, want 10", buckets[len(buckets)-1].UpperBound)
+	}
+}
+
+func TestHistogramConstantValues(t *testing.T) {
+	buckets := Histogram([]float64{5, 5, 5}, 4)
+	if len(buckets) != 1 || buckets[0].Count != 3 {
+		t.Errorf("Histogram of constant values = %+v, want a single bucket with Count 3", buckets)
+	}
+}